@@ -0,0 +1,66 @@
+package pool
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/c-osmosis/osmosis/x/gamm/types"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	metrics "github.com/hashicorp/go-metrics"
+)
+
+// representativeSpotPrice returns the post-operation spot price between
+// the two lexicographically-first denoms bound to pool. A pool with more
+// than two assets has no single "the" price, so this pair is used
+// consistently across every lifecycle event as the pool's representative
+// quote. Any failure to compute it (e.g. a still-converging stableswap
+// pool) yields a zero price rather than failing the operation the event
+// is describing.
+func representativeSpotPrice(pool types.Pool) sdk.Dec {
+	denoms := pool.SortedDenoms()
+	if len(denoms) < 2 {
+		return sdk.ZeroDec()
+	}
+
+	pm, err := newPoolMath(pool.PoolType)
+	if err != nil {
+		return sdk.ZeroDec()
+	}
+	price, err := pm.SpotPrice(pool, denoms[0], denoms[1])
+	if err != nil {
+		return sdk.ZeroDec()
+	}
+	return price
+}
+
+// emitPoolEvent emits an sdk event for a pool lifecycle operation, always
+// tagging it with the pool id, the sender, and the pool's post-operation
+// representative spot price, in addition to whatever op-specific
+// attributes the caller supplies.
+func emitPoolEvent(ctx sdk.Context, eventType string, pool types.Pool, sender sdk.AccAddress, attrs ...sdk.Attribute) {
+	eventAttrs := append([]sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyPoolId, strconv.FormatUint(pool.Id, 10)),
+		sdk.NewAttribute(types.AttributeKeySender, sender.String()),
+		sdk.NewAttribute(types.AttributeKeySpotPriceAfter, representativeSpotPrice(pool).String()),
+	}, attrs...)
+	ctx.EventManager().EmitEvent(sdk.NewEvent(eventType, eventAttrs...))
+}
+
+// recordPoolOpMetrics records op latency (metrics.MeasureSinceWithLabels)
+// and increments an op-count counter, both labeled by pool id and operation
+// type. Call it via defer right after fetching or creating the pool:
+//
+//	defer recordPoolOpMetrics(time.Now(), types.EventTypeJoinPool, pool.Id)
+func recordPoolOpMetrics(start time.Time, opType string, poolId uint64) {
+	labels := []metrics.Label{
+		telemetry.NewLabel("pool_id", strconv.FormatUint(poolId, 10)),
+		telemetry.NewLabel("operation", opType),
+	}
+	metrics.MeasureSinceWithLabels([]string{types.ModuleName, opType, "latency"}, start.UTC(), labels)
+	telemetry.IncrCounterWithLabels(
+		[]string{types.ModuleName, opType, "count"},
+		1,
+		labels,
+	)
+}
@@ -0,0 +1,65 @@
+package pool
+
+import (
+	"github.com/c-osmosis/osmosis/x/gamm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// PoolCircuitBreaker is the admin-facing counterpart to the governance
+// proposal handler in proposal_handler.go: it lets a pool's Admin freeze
+// or unfreeze it directly, without a governance vote.
+type PoolCircuitBreaker interface {
+	FreezePool(ctx sdk.Context, sender sdk.AccAddress, poolId uint64) error
+	UnfreezePool(ctx sdk.Context, sender sdk.AccAddress, poolId uint64) error
+}
+
+var _ PoolCircuitBreaker = poolService{}
+
+func (p poolService) FreezePool(ctx sdk.Context, sender sdk.AccAddress, poolId uint64) error {
+	return p.setFrozen(ctx, &sender, poolId, true)
+}
+
+func (p poolService) UnfreezePool(ctx sdk.Context, sender sdk.AccAddress, poolId uint64) error {
+	return p.setFrozen(ctx, &sender, poolId, false)
+}
+
+// freezePoolByProposal and unfreezePoolByProposal are called from the
+// governance proposal handler, which only ever routes proposals that have
+// already passed a vote, so no sender to authorize against is needed.
+func (p poolService) freezePoolByProposal(ctx sdk.Context, poolId uint64) error {
+	return p.setFrozen(ctx, nil, poolId, true)
+}
+
+func (p poolService) unfreezePoolByProposal(ctx sdk.Context, poolId uint64) error {
+	return p.setFrozen(ctx, nil, poolId, false)
+}
+
+// setFrozen flips pool.Frozen and persists it. A nil sender means the
+// caller is already authorized (the governance proposal handler); a
+// non-nil sender must match pool.Admin.
+func (p poolService) setFrozen(ctx sdk.Context, sender *sdk.AccAddress, poolId uint64, frozen bool) error {
+	pool, err := p.store.FetchPool(ctx, poolId)
+	if err != nil {
+		return err
+	}
+	if sender != nil {
+		if pool.Admin.Empty() || !pool.Admin.Equals(*sender) {
+			return sdkerrors.Wrapf(types.ErrUnauthorized, "sender %s is not pool %d's admin", sender, poolId)
+		}
+	}
+
+	pool.Frozen = frozen
+	p.store.StorePool(ctx, pool)
+
+	eventType := types.EventTypeUnfreezePool
+	if frozen {
+		eventType = types.EventTypeFreezePool
+	}
+	eventSender := sdk.AccAddress{}
+	if sender != nil {
+		eventSender = *sender
+	}
+	emitPoolEvent(ctx, eventType, pool, eventSender)
+	return nil
+}
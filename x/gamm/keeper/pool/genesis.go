@@ -0,0 +1,57 @@
+package pool
+
+import (
+	"github.com/c-osmosis/osmosis/x/gamm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// InitGenesis validates genState's cross-invariants, then loads every
+// pool it contains into the store and seeds the next pool number counter
+// so newly created pools don't collide with imported ones.
+func (p poolService) InitGenesis(ctx sdk.Context, genState types.GenesisState) error {
+	if err := genState.Validate(); err != nil {
+		return err
+	}
+
+	p.SetParams(ctx, genState.Params)
+
+	for _, pool := range genState.Pools {
+		p.store.StorePool(ctx, pool)
+	}
+	p.store.SetNextPoolNumber(ctx, genState.NextPoolNumber)
+	return nil
+}
+
+// ExportGenesis dumps every stored pool plus a snapshot of each pool's
+// LP-share distribution, taken by scanning the bank keeper's balances for
+// every pool.Token.Denom.
+func (p poolService) ExportGenesis(ctx sdk.Context) types.GenesisState {
+	var pools []types.Pool
+	poolIdByShareDenom := map[string]uint64{}
+	p.store.IterateAllPools(ctx, func(pool types.Pool) bool {
+		pools = append(pools, pool)
+		poolIdByShareDenom[pool.Token.Denom] = pool.Id
+		return false
+	})
+
+	var shareRecords []types.ShareRecord
+	p.bankKeeper.IterateAllBalances(ctx, func(addr sdk.AccAddress, coin sdk.Coin) bool {
+		poolId, ok := poolIdByShareDenom[coin.Denom]
+		if !ok {
+			return false
+		}
+		shareRecords = append(shareRecords, types.ShareRecord{
+			PoolId:  poolId,
+			Address: addr,
+			Shares:  coin.Amount,
+		})
+		return false
+	})
+
+	return types.GenesisState{
+		Pools:            pools,
+		NextPoolNumber:   p.store.GetNextPoolNumber(ctx),
+		PoolShareRecords: shareRecords,
+		Params:           p.GetParams(ctx),
+	}
+}
@@ -0,0 +1,28 @@
+package pool
+
+import (
+	"github.com/c-osmosis/osmosis/x/gamm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// lpService mints, burns, and transfers the LP share token of a single pool.
+type lpService struct {
+	denom      string
+	bankKeeper bankKeeper
+}
+
+func (l lpService) mintPoolShare(ctx sdk.Context, amount sdk.Int) error {
+	return l.bankKeeper.MintCoins(ctx, types.ModuleName, sdk.Coins{sdk.NewCoin(l.denom, amount)})
+}
+
+func (l lpService) burnPoolShare(ctx sdk.Context, amount sdk.Int) error {
+	return l.bankKeeper.BurnCoins(ctx, types.ModuleName, sdk.Coins{sdk.NewCoin(l.denom, amount)})
+}
+
+func (l lpService) pushPoolShare(ctx sdk.Context, to sdk.AccAddress, amount sdk.Int) error {
+	return l.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, to, sdk.Coins{sdk.NewCoin(l.denom, amount)})
+}
+
+func (l lpService) pullPoolShare(ctx sdk.Context, from sdk.AccAddress, amount sdk.Int) error {
+	return l.bankKeeper.SendCoinsFromAccountToModule(ctx, from, types.ModuleName, sdk.Coins{sdk.NewCoin(l.denom, amount)})
+}
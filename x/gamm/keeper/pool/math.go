@@ -0,0 +1,210 @@
+package pool
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// powApprox computes base^exp for a base in (0, 2) and an exponent in
+// [-1, 1], using the binomial series expansion Balancer's BPow relies on.
+// Outside that domain callers are expected to have already peeled off the
+// integer part of the exponent via sdk.Dec.Power.
+func powApprox(base sdk.Dec, exp sdk.Dec) sdk.Dec {
+	if exp.IsZero() {
+		return sdk.OneDec()
+	}
+	if base.IsZero() {
+		return sdk.ZeroDec()
+	}
+
+	x, xneg := absDifferenceWithSign(base, sdk.OneDec())
+	term := sdk.OneDec()
+	sum := sdk.OneDec()
+	negative := false
+
+	// a := exp, x := |base - 1|. Expand (1+x)^a = sum_k C(a,k) x^k.
+	a := exp
+	for i := 1; i <= 8; i++ {
+		bigK := sdk.NewDec(int64(i))
+		cPow, cNeg := absDifferenceWithSign(a, bigK.Sub(sdk.OneDec()))
+		term = term.Mul(cPow.Mul(x)).Quo(bigK)
+		if term.IsZero() {
+			break
+		}
+		if xneg {
+			cNeg = !cNeg
+		}
+		if cNeg {
+			negative = !negative
+		}
+		if negative {
+			sum = sum.Sub(term)
+		} else {
+			sum = sum.Add(term)
+		}
+	}
+	return sum
+}
+
+// absDifferenceWithSign returns |a - b| along with whether a < b.
+func absDifferenceWithSign(a, b sdk.Dec) (sdk.Dec, bool) {
+	if a.GTE(b) {
+		return a.Sub(b), false
+	}
+	return b.Sub(a), true
+}
+
+// pow computes base^exp for a positive base and a non-negative exponent by
+// splitting exp into an integer part (handled exactly via sdk.Dec.Power)
+// and a fractional part (handled via powApprox).
+func pow(base sdk.Dec, exp sdk.Dec) sdk.Dec {
+	integer := exp.TruncateDec()
+	fractional := exp.Sub(integer)
+
+	integerPow := base.Power(uint64(integer.TruncateInt64()))
+	if fractional.IsZero() {
+		return integerPow
+	}
+	return integerPow.Mul(powApprox(base, fractional))
+}
+
+// calcSpotPrice returns the effective price of tokenOut denominated in
+// tokenIn (tokenIn paid per unit of tokenOut) for a weighted pool,
+// inclusive of the swap fee.
+func calcSpotPrice(
+	tokenBalanceIn sdk.Dec,
+	tokenWeightIn sdk.Dec,
+	tokenBalanceOut sdk.Dec,
+	tokenWeightOut sdk.Dec,
+	swapFee sdk.Dec,
+) sdk.Dec {
+	number := tokenBalanceIn.Quo(tokenWeightIn)
+	denom := tokenBalanceOut.Quo(tokenWeightOut)
+	ratio := number.Quo(denom)
+	return ratio.Quo(sdk.OneDec().Sub(swapFee))
+}
+
+// calcOutGivenIn is the constant-weighted-product swap formula: given an
+// exact tokenAmountIn, how much of tokenOut a trader receives.
+func calcOutGivenIn(
+	tokenBalanceIn sdk.Dec,
+	tokenWeightIn sdk.Dec,
+	tokenBalanceOut sdk.Dec,
+	tokenWeightOut sdk.Dec,
+	tokenAmountIn sdk.Dec,
+	swapFee sdk.Dec,
+) sdk.Dec {
+	weightRatio := tokenWeightIn.Quo(tokenWeightOut)
+	adjustedIn := tokenAmountIn.Mul(sdk.OneDec().Sub(swapFee))
+	y := tokenBalanceIn.Quo(tokenBalanceIn.Add(adjustedIn))
+	foo := pow(y, weightRatio)
+	bar := sdk.OneDec().Sub(foo)
+	return tokenBalanceOut.Mul(bar)
+}
+
+// calcInGivenOut is the inverse of calcOutGivenIn: given an exact
+// tokenAmountOut a trader wants, how much of tokenIn they must pay in.
+func calcInGivenOut(
+	tokenBalanceIn sdk.Dec,
+	tokenWeightIn sdk.Dec,
+	tokenBalanceOut sdk.Dec,
+	tokenWeightOut sdk.Dec,
+	tokenAmountOut sdk.Dec,
+	swapFee sdk.Dec,
+) sdk.Dec {
+	weightRatio := tokenWeightOut.Quo(tokenWeightIn)
+	diff := tokenBalanceOut.Sub(tokenAmountOut)
+	y := tokenBalanceOut.Quo(diff)
+	foo := pow(y, weightRatio).Sub(sdk.OneDec())
+	return tokenBalanceIn.Mul(foo).Quo(sdk.OneDec().Sub(swapFee))
+}
+
+// calcPoolOutGivenSingleIn returns the pool shares minted for a
+// single-asset join of tokenAmountIn of one bound token.
+func calcPoolOutGivenSingleIn(
+	tokenBalanceIn sdk.Dec,
+	tokenWeightIn sdk.Dec,
+	poolSupply sdk.Dec,
+	totalWeight sdk.Dec,
+	tokenAmountIn sdk.Dec,
+	swapFee sdk.Dec,
+) sdk.Dec {
+	normalizedWeight := tokenWeightIn.Quo(totalWeight)
+	zaz := sdk.OneDec().Sub(normalizedWeight).Mul(swapFee)
+	tokenAmountInAfterFee := tokenAmountIn.Mul(sdk.OneDec().Sub(zaz))
+
+	newTokenBalanceIn := tokenBalanceIn.Add(tokenAmountInAfterFee)
+	tokenInRatio := newTokenBalanceIn.Quo(tokenBalanceIn)
+
+	poolRatio := pow(tokenInRatio, normalizedWeight)
+	newPoolSupply := poolRatio.Mul(poolSupply)
+	return newPoolSupply.Sub(poolSupply)
+}
+
+// calcSingleInGivenPoolOut is the inverse of calcPoolOutGivenSingleIn: the
+// amount of a single bound token required to mint an exact poolAmountOut.
+func calcSingleInGivenPoolOut(
+	tokenBalanceIn sdk.Dec,
+	tokenWeightIn sdk.Dec,
+	poolSupply sdk.Dec,
+	totalWeight sdk.Dec,
+	poolAmountOut sdk.Dec,
+	swapFee sdk.Dec,
+) sdk.Dec {
+	normalizedWeight := tokenWeightIn.Quo(totalWeight)
+	newPoolSupply := poolSupply.Add(poolAmountOut)
+	poolRatio := newPoolSupply.Quo(poolSupply)
+
+	boo := sdk.OneDec().Quo(normalizedWeight)
+	tokenInRatio := pow(poolRatio, boo)
+	newTokenBalanceIn := tokenInRatio.Mul(tokenBalanceIn)
+	tokenAmountInAfterFee := newTokenBalanceIn.Sub(tokenBalanceIn)
+
+	zar := sdk.OneDec().Sub(normalizedWeight).Mul(swapFee)
+	return tokenAmountInAfterFee.Quo(sdk.OneDec().Sub(zar))
+}
+
+// calcSingleOutGivenPoolIn returns the amount of a single bound token paid
+// out for burning an exact poolAmountIn.
+func calcSingleOutGivenPoolIn(
+	tokenBalanceOut sdk.Dec,
+	tokenWeightOut sdk.Dec,
+	poolSupply sdk.Dec,
+	totalWeight sdk.Dec,
+	poolAmountIn sdk.Dec,
+	swapFee sdk.Dec,
+) sdk.Dec {
+	normalizedWeight := tokenWeightOut.Quo(totalWeight)
+	newPoolSupply := poolSupply.Sub(poolAmountIn)
+	poolRatio := newPoolSupply.Quo(poolSupply)
+
+	tokenOutRatio := pow(poolRatio, sdk.OneDec().Quo(normalizedWeight))
+	newTokenBalanceOut := tokenOutRatio.Mul(tokenBalanceOut)
+	tokenAmountOutBeforeSwapFee := tokenBalanceOut.Sub(newTokenBalanceOut)
+
+	zaz := sdk.OneDec().Sub(normalizedWeight).Mul(swapFee)
+	return tokenAmountOutBeforeSwapFee.Mul(sdk.OneDec().Sub(zaz))
+}
+
+// calcPoolInGivenSingleOut is the inverse of calcSingleOutGivenPoolIn: the
+// pool shares that must be burned to withdraw an exact tokenAmountOut of a
+// single bound token.
+func calcPoolInGivenSingleOut(
+	tokenBalanceOut sdk.Dec,
+	tokenWeightOut sdk.Dec,
+	poolSupply sdk.Dec,
+	totalWeight sdk.Dec,
+	tokenAmountOut sdk.Dec,
+	swapFee sdk.Dec,
+) sdk.Dec {
+	normalizedWeight := tokenWeightOut.Quo(totalWeight)
+	zoo := sdk.OneDec().Sub(normalizedWeight)
+	zar := zoo.Mul(swapFee)
+	tokenAmountOutBeforeSwapFee := tokenAmountOut.Quo(sdk.OneDec().Sub(zar))
+
+	newTokenBalanceOut := tokenBalanceOut.Sub(tokenAmountOutBeforeSwapFee)
+	tokenOutRatio := newTokenBalanceOut.Quo(tokenBalanceOut)
+
+	poolRatio := pow(tokenOutRatio, normalizedWeight)
+	newPoolSupply := poolRatio.Mul(poolSupply)
+	return poolSupply.Sub(newPoolSupply)
+}
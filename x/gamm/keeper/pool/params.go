@@ -0,0 +1,68 @@
+package pool
+
+import (
+	"github.com/c-osmosis/osmosis/x/gamm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// GetParams returns the gamm module's current params.
+func (p poolService) GetParams(ctx sdk.Context) types.Params {
+	var params types.Params
+	p.paramSpace.GetParamSet(ctx, &params)
+	return params
+}
+
+// SetParams sets the gamm module's params. It is called from InitGenesis;
+// governance param-change proposals update the same subspace through the
+// x/params proposal handler without going through SetParams.
+func (p poolService) SetParams(ctx sdk.Context, params types.Params) {
+	p.paramSpace.SetParamSet(ctx, &params)
+}
+
+func (p poolService) maxInRatio(ctx sdk.Context) sdk.Dec {
+	var v sdk.Dec
+	p.paramSpace.Get(ctx, types.KeyMaxInRatio, &v)
+	return v
+}
+
+func (p poolService) maxOutRatio(ctx sdk.Context) sdk.Dec {
+	var v sdk.Dec
+	p.paramSpace.Get(ctx, types.KeyMaxOutRatio, &v)
+	return v
+}
+
+func (p poolService) minPoolAssets(ctx sdk.Context) uint32 {
+	var v uint32
+	p.paramSpace.Get(ctx, types.KeyMinPoolAssets, &v)
+	return v
+}
+
+func (p poolService) maxPoolAssets(ctx sdk.Context) uint32 {
+	var v uint32
+	p.paramSpace.Get(ctx, types.KeyMaxPoolAssets, &v)
+	return v
+}
+
+func (p poolService) initialPoolShares(ctx sdk.Context) sdk.Int {
+	var v sdk.Int
+	p.paramSpace.Get(ctx, types.KeyInitialPoolShares, &v)
+	return v
+}
+
+func (p poolService) poolCreationFee(ctx sdk.Context) sdk.Coins {
+	var v sdk.Coins
+	p.paramSpace.Get(ctx, types.KeyPoolCreationFee, &v)
+	return v
+}
+
+func (p poolService) validateSwapFee(ctx sdk.Context, swapFee sdk.Dec) error {
+	params := p.GetParams(ctx)
+	if swapFee.LT(params.MinSwapFee) || swapFee.GT(params.MaxSwapFee) {
+		return sdkerrors.Wrapf(
+			types.ErrInvalidRequest,
+			"swap fee %s outside allowed range [%s, %s]", swapFee, params.MinSwapFee, params.MaxSwapFee,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package pool
+
+import (
+	"github.com/c-osmosis/osmosis/x/gamm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// minAmplification and maxAmplification bound the stableswap "A"
+// parameter accepted by CreatePool.
+var (
+	minAmplification = sdk.OneInt()
+	maxAmplification = sdk.NewInt(1e6)
+)
+
+// PoolMath is implemented once per types.PoolType and supplies the
+// invariant-specific pieces of CreatePool and the single-asset join/exit
+// paths. Proportional JoinPool/ExitPool scale every bound asset by the
+// same poolRatio regardless of invariant, so they are not routed through
+// PoolMath - only operations that depend on the shape of the invariant
+// curve are.
+type PoolMath interface {
+	// ValidateCreatePool checks invariant-specific CreatePool inputs,
+	// e.g. per-asset weights for weighted pools or the amplification
+	// factor for stableswap pools.
+	ValidateCreatePool(bindTokens []types.BindTokenInfo, amplification sdk.Int) error
+
+	PoolOutGivenSingleIn(pool types.Pool, tokenInDenom string, tokenAmountIn sdk.Dec) (sdk.Dec, error)
+	SingleInGivenPoolOut(pool types.Pool, tokenInDenom string, poolAmountOut sdk.Dec) (sdk.Dec, error)
+	SingleOutGivenPoolIn(pool types.Pool, tokenOutDenom string, poolAmountIn sdk.Dec) (sdk.Dec, error)
+	PoolInGivenSingleOut(pool types.Pool, tokenOutDenom string, tokenAmountOut sdk.Dec) (sdk.Dec, error)
+
+	// OutGivenIn and InGivenOut are the two-asset spot-swap formulas
+	// SwapExactAmountIn/SwapExactAmountOut are built on: how much of
+	// tokenOutDenom a trader gets for an exact tokenAmountIn, and how
+	// much of tokenInDenom a trader must pay for an exact
+	// tokenAmountOut, respectively.
+	OutGivenIn(pool types.Pool, tokenInDenom, tokenOutDenom string, tokenAmountIn sdk.Dec) (sdk.Dec, error)
+	InGivenOut(pool types.Pool, tokenInDenom, tokenOutDenom string, tokenAmountOut sdk.Dec) (sdk.Dec, error)
+
+	// SpotPrice returns the pool's current marginal price of quoteDenom
+	// denominated in baseDenom: how much baseDenom one unit of quoteDenom
+	// costs.
+	SpotPrice(pool types.Pool, baseDenom, quoteDenom string) (sdk.Dec, error)
+}
+
+// newPoolMath resolves the PoolMath implementation for a pool's type.
+func newPoolMath(poolType types.PoolType) (PoolMath, error) {
+	switch poolType {
+	case types.PoolTypeWeighted:
+		return weightedPoolMath{}, nil
+	case types.PoolTypeStableswap:
+		return stableswapPoolMath{}, nil
+	default:
+		return nil, sdkerrors.Wrapf(types.ErrInvalidRequest, "unknown pool type %d", poolType)
+	}
+}
+
+// weightedPoolMath implements PoolMath for PoolTypeWeighted in terms of
+// the existing Balancer-style calc functions.
+type weightedPoolMath struct{}
+
+var _ PoolMath = weightedPoolMath{}
+
+func (weightedPoolMath) ValidateCreatePool(bindTokens []types.BindTokenInfo, _ sdk.Int) error {
+	for _, info := range bindTokens {
+		if !info.Weight.IsPositive() {
+			return sdkerrors.Wrapf(types.ErrInvalidRequest, "weight of %s must be positive", info.Denom)
+		}
+	}
+	return nil
+}
+
+func (weightedPoolMath) PoolOutGivenSingleIn(pool types.Pool, tokenInDenom string, tokenAmountIn sdk.Dec) (sdk.Dec, error) {
+	record := pool.Records[tokenInDenom]
+	return calcPoolOutGivenSingleIn(
+		record.Balance.ToDec(), record.DenormalizedWeight,
+		pool.Token.TotalSupply.ToDec(), pool.TotalWeight,
+		tokenAmountIn, pool.SwapFee,
+	), nil
+}
+
+func (weightedPoolMath) SingleInGivenPoolOut(pool types.Pool, tokenInDenom string, poolAmountOut sdk.Dec) (sdk.Dec, error) {
+	record := pool.Records[tokenInDenom]
+	return calcSingleInGivenPoolOut(
+		record.Balance.ToDec(), record.DenormalizedWeight,
+		pool.Token.TotalSupply.ToDec(), pool.TotalWeight,
+		poolAmountOut, pool.SwapFee,
+	), nil
+}
+
+func (weightedPoolMath) SingleOutGivenPoolIn(pool types.Pool, tokenOutDenom string, poolAmountIn sdk.Dec) (sdk.Dec, error) {
+	record := pool.Records[tokenOutDenom]
+	return calcSingleOutGivenPoolIn(
+		record.Balance.ToDec(), record.DenormalizedWeight,
+		pool.Token.TotalSupply.ToDec(), pool.TotalWeight,
+		poolAmountIn, pool.SwapFee,
+	), nil
+}
+
+func (weightedPoolMath) PoolInGivenSingleOut(pool types.Pool, tokenOutDenom string, tokenAmountOut sdk.Dec) (sdk.Dec, error) {
+	record := pool.Records[tokenOutDenom]
+	return calcPoolInGivenSingleOut(
+		record.Balance.ToDec(), record.DenormalizedWeight,
+		pool.Token.TotalSupply.ToDec(), pool.TotalWeight,
+		tokenAmountOut, pool.SwapFee,
+	), nil
+}
+
+func (weightedPoolMath) OutGivenIn(pool types.Pool, tokenInDenom, tokenOutDenom string, tokenAmountIn sdk.Dec) (sdk.Dec, error) {
+	recordIn, ok := pool.Records[tokenInDenom]
+	if !ok {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrNotBound, "token %s is not bound to this pool", tokenInDenom)
+	}
+	recordOut, ok := pool.Records[tokenOutDenom]
+	if !ok {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrNotBound, "token %s is not bound to this pool", tokenOutDenom)
+	}
+	return calcOutGivenIn(
+		recordIn.Balance.ToDec(), recordIn.DenormalizedWeight,
+		recordOut.Balance.ToDec(), recordOut.DenormalizedWeight,
+		tokenAmountIn, pool.SwapFee,
+	), nil
+}
+
+func (weightedPoolMath) InGivenOut(pool types.Pool, tokenInDenom, tokenOutDenom string, tokenAmountOut sdk.Dec) (sdk.Dec, error) {
+	recordIn, ok := pool.Records[tokenInDenom]
+	if !ok {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrNotBound, "token %s is not bound to this pool", tokenInDenom)
+	}
+	recordOut, ok := pool.Records[tokenOutDenom]
+	if !ok {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrNotBound, "token %s is not bound to this pool", tokenOutDenom)
+	}
+	return calcInGivenOut(
+		recordIn.Balance.ToDec(), recordIn.DenormalizedWeight,
+		recordOut.Balance.ToDec(), recordOut.DenormalizedWeight,
+		tokenAmountOut, pool.SwapFee,
+	), nil
+}
+
+func (weightedPoolMath) SpotPrice(pool types.Pool, baseDenom, quoteDenom string) (sdk.Dec, error) {
+	base, ok := pool.Records[baseDenom]
+	if !ok {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrNotBound, "token %s is not bound to this pool", baseDenom)
+	}
+	quote, ok := pool.Records[quoteDenom]
+	if !ok {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrNotBound, "token %s is not bound to this pool", quoteDenom)
+	}
+	return calcSpotPrice(
+		base.Balance.ToDec(), base.DenormalizedWeight,
+		quote.Balance.ToDec(), quote.DenormalizedWeight,
+		pool.SwapFee,
+	), nil
+}
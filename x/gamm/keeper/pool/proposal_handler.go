@@ -0,0 +1,28 @@
+package pool
+
+import (
+	"github.com/c-osmosis/osmosis/x/gamm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+// NewPoolProposalHandler returns a governance proposal handler for the
+// gamm module's pool circuit breaker, to be registered with the gov
+// module's router alongside the standard x/params ParamChangeProposal
+// handler.
+func NewPoolProposalHandler(p poolService) govtypes.Handler {
+	return func(ctx sdk.Context, content govtypes.Content) error {
+		switch c := content.(type) {
+		case *types.FreezePoolProposal:
+			return p.freezePoolByProposal(ctx, c.PoolId)
+		case *types.UnfreezePoolProposal:
+			return p.unfreezePoolByProposal(ctx, c.PoolId)
+		default:
+			return sdkerrors.Wrapf(
+				types.ErrInvalidRequest,
+				"unrecognized gamm proposal content type: %T", c,
+			)
+		}
+	}
+}
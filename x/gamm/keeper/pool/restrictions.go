@@ -0,0 +1,34 @@
+package pool
+
+import (
+	"github.com/c-osmosis/osmosis/x/gamm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+// NewSendRestriction returns a bank SendRestrictionFn enforcing
+// TransferRestricted pools: their LP share denom may only move to or from
+// the gamm module account (i.e. during JoinPool/ExitPool), never directly
+// between two regular accounts. It is meant to be registered with the
+// bank keeper via AppendSendRestriction at app wiring time; it does not
+// itself run as part of any pool operation in this package.
+func NewSendRestriction(store poolStore) banktypes.SendRestrictionFn {
+	moduleAddr := authtypes.NewModuleAddress(types.ModuleName)
+	return func(ctx sdk.Context, fromAddr, toAddr sdk.AccAddress, amt sdk.Coins) (sdk.AccAddress, error) {
+		if fromAddr.Equals(moduleAddr) || toAddr.Equals(moduleAddr) {
+			return toAddr, nil
+		}
+		for _, coin := range amt {
+			pool, ok := store.FetchPoolByShareDenom(ctx, coin.Denom)
+			if ok && pool.TransferRestricted {
+				return toAddr, sdkerrors.Wrapf(
+					types.ErrUnauthorized,
+					"%s may only be transferred to or from the gamm module account", coin.Denom,
+				)
+			}
+		}
+		return toAddr, nil
+	}
+}
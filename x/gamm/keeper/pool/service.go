@@ -0,0 +1,57 @@
+package pool
+
+import (
+	"github.com/c-osmosis/osmosis/x/gamm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+)
+
+// poolStore is the persistence surface poolService needs out of the gamm
+// keeper's KVStore wrapper.
+type poolStore interface {
+	GetNextPoolNumber(ctx sdk.Context) uint64
+	SetNextPoolNumber(ctx sdk.Context, poolNumber uint64)
+	FetchPool(ctx sdk.Context, poolId uint64) (types.Pool, error)
+	// FetchPoolByShareDenom looks a pool up by its LP share denom, via a
+	// secondary index maintained alongside the primary by-id store. It is
+	// the lookup the bank SendRestrictionFn needs on every transfer, so it
+	// must not cost an IterateAllPools scan.
+	FetchPoolByShareDenom(ctx sdk.Context, denom string) (types.Pool, bool)
+	StorePool(ctx sdk.Context, pool types.Pool)
+	IterateAllPools(ctx sdk.Context, cb func(pool types.Pool) (stop bool))
+}
+
+// bankKeeper is the subset of the bank keeper poolService relies on to move
+// pool assets and LP shares around.
+type bankKeeper interface {
+	SendCoinsFromAccountToModule(ctx sdk.Context, senderAddr sdk.AccAddress, recipientModule string, amt sdk.Coins) error
+	SendCoinsFromModuleToAccount(ctx sdk.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) error
+	MintCoins(ctx sdk.Context, moduleName string, amt sdk.Coins) error
+	BurnCoins(ctx sdk.Context, moduleName string, amt sdk.Coins) error
+	SendCoinsFromAccountToAccount(ctx sdk.Context, senderAddr, recipientAddr sdk.AccAddress, amt sdk.Coins) error
+	IterateAllBalances(ctx sdk.Context, cb func(address sdk.AccAddress, coin sdk.Coin) (stop bool))
+}
+
+// poolService implements LiquidityPoolTransactor on top of a poolStore and
+// bankKeeper.
+type poolService struct {
+	store      poolStore
+	bankKeeper bankKeeper
+	paramSpace paramtypes.Subspace
+}
+
+// NewPoolService constructs a poolService backed by the given store and bank
+// keeper. paramSpace must be the gamm module's subspace, as registered with
+// the app's params keeper; it is given the module's key table here if it
+// doesn't already have one, mirroring how other cosmos-sdk modules wire up
+// governance-controlled params.
+func NewPoolService(store poolStore, bankKeeper bankKeeper, paramSpace paramtypes.Subspace) poolService {
+	if !paramSpace.HasKeyTable() {
+		paramSpace = paramSpace.WithKeyTable(types.ParamKeyTable())
+	}
+	return poolService{
+		store:      store,
+		bankKeeper: bankKeeper,
+		paramSpace: paramSpace,
+	}
+}
@@ -2,6 +2,7 @@ package pool
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/c-osmosis/osmosis/x/gamm/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -15,6 +16,8 @@ type LiquidityPoolTransactor interface {
 		swapFee sdk.Dec,
 		lpToken types.LPTokenInfo,
 		bindTokens []types.BindTokenInfo,
+		poolType types.PoolType,
+		amplification sdk.Int,
 	) (poolId uint64, err error)
 
 	JoinPool(
@@ -79,6 +82,10 @@ func (p poolService) joinPool(
 	swapTargets sdk.Coins,
 	swapAmount sdk.Int,
 ) error {
+	if pool.Frozen {
+		return sdkerrors.Wrapf(types.ErrPoolFrozen, "pool %d is frozen", pool.Id)
+	}
+
 	// process token transfers
 	poolShare := lpService{
 		denom:      pool.Token.Denom,
@@ -116,19 +123,34 @@ func (p poolService) CreatePool(
 	swapFee sdk.Dec,
 	lpToken types.LPTokenInfo,
 	bindTokens []types.BindTokenInfo,
+	poolType types.PoolType,
+	amplification sdk.Int,
 ) (uint64, error) {
-	if len(bindTokens) < 2 {
+	start := time.Now()
+	minPoolAssets, maxPoolAssets := p.minPoolAssets(ctx), p.maxPoolAssets(ctx)
+	if uint32(len(bindTokens)) < minPoolAssets {
 		return 0, sdkerrors.Wrapf(
 			types.ErrInvalidRequest,
-			"token info length should be at least 2",
+			"token info length should be at least %d", minPoolAssets,
 		)
 	}
-	if len(bindTokens) > 8 {
+	if uint32(len(bindTokens)) > maxPoolAssets {
 		return 0, sdkerrors.Wrapf(
 			types.ErrInvalidRequest,
-			"token info length should be at maximum 8",
+			"token info length should be at maximum %d", maxPoolAssets,
 		)
 	}
+	if err := p.validateSwapFee(ctx, swapFee); err != nil {
+		return 0, err
+	}
+
+	pm, err := newPoolMath(poolType)
+	if err != nil {
+		return 0, err
+	}
+	if err := pm.ValidateCreatePool(bindTokens, amplification); err != nil {
+		return 0, err
+	}
 
 	records := make(map[string]types.Record, len(bindTokens))
 	for _, info := range bindTokens {
@@ -139,17 +161,13 @@ func (p poolService) CreatePool(
 	}
 
 	poolId := p.store.GetNextPoolNumber(ctx)
+	defer func() { recordPoolOpMetrics(start, types.EventTypeCreatePool, poolId) }()
 	if lpToken.Denom == "" {
 		lpToken.Denom = fmt.Sprintf("osmosis/pool/%d", poolId)
 	} else {
 		lpToken.Denom = fmt.Sprintf("osmosis/custom/%s", lpToken.Denom)
 	}
 
-	totalWeight := sdk.NewDec(0)
-	for _, record := range records {
-		totalWeight = totalWeight.Add(record.DenormalizedWeight)
-	}
-
 	pool := types.Pool{
 		Id:      poolId,
 		SwapFee: swapFee,
@@ -158,28 +176,63 @@ func (p poolService) CreatePool(
 			Description: lpToken.Description,
 			TotalSupply: sdk.NewInt(0),
 		},
-		TotalWeight: totalWeight,
-		Records:     records,
+		PoolType:           poolType,
+		Amplification:      amplification,
+		Records:            records,
+		Admin:              sender,
+		TransferRestricted: lpToken.TransferRestricted,
 	}
 
-	p.store.StorePool(ctx, pool)
-
-	var coins sdk.Coins
-	for denom, record := range records {
+	// Records is a map, so ranging over it directly would make
+	// TotalWeight and the initial bind-token coins nondeterministic
+	// across nodes; go by SortedDenoms instead.
+	denoms := pool.SortedDenoms()
+	totalWeight := sdk.NewDec(0)
+	coins := make(sdk.Coins, 0, len(denoms))
+	for _, denom := range denoms {
+		record := records[denom]
+		// Stableswap bind tokens carry no weight (ValidateCreatePool
+		// doesn't require one), so DenormalizedWeight is an unset Dec
+		// there; summing it would panic. TotalWeight only means
+		// anything for weighted pools.
+		if poolType == types.PoolTypeWeighted {
+			totalWeight = totalWeight.Add(record.DenormalizedWeight)
+		}
 		coins = append(coins, sdk.Coin{
 			Denom:  denom,
 			Amount: record.Balance,
 		})
 	}
-	if coins == nil {
-		panic("oh my god")
+	pool.TotalWeight = totalWeight
+
+	p.store.StorePool(ctx, pool)
+
+	if len(coins) == 0 {
+		return 0, sdkerrors.Wrapf(types.ErrInvalidRequest, "pool must bind at least one token")
 	}
 	coins = coins.Sort()
 
-	initialSupply := sdk.NewIntWithDecimal(100, 6)
+	if creationFee := p.poolCreationFee(ctx); !creationFee.Empty() {
+		if err := p.bankKeeper.SendCoinsFromAccountToModule(ctx, sender, types.ModuleName, creationFee); err != nil {
+			return 0, err
+		}
+		// Burn rather than hold the fee in the module account: it isn't
+		// owed to any LP, so leaving it there would sit beside the pools'
+		// bound assets without being recorded in any pool's Records.
+		if err := p.bankKeeper.BurnCoins(ctx, types.ModuleName, creationFee); err != nil {
+			return 0, err
+		}
+	}
+
+	initialSupply := p.initialPoolShares(ctx)
 	if err := p.joinPool(ctx, sender, pool, coins, initialSupply); err != nil {
 		return 0, err
 	}
+
+	emitPoolEvent(ctx, types.EventTypeCreatePool, pool, sender,
+		sdk.NewAttribute(types.AttributeKeyTokensIn, coins.String()),
+		sdk.NewAttribute(types.AttributeKeyPoolSharesOut, initialSupply.String()),
+	)
 	return pool.Id, nil
 }
 
@@ -194,6 +247,7 @@ func (p poolService) JoinPool(
 	if err != nil {
 		return err
 	}
+	defer recordPoolOpMetrics(time.Now(), types.EventTypeJoinPool, pool.Id)
 	lpToken := pool.Token
 
 	poolTotal := lpToken.TotalSupply.ToDec()
@@ -240,7 +294,14 @@ func (p poolService) JoinPool(
 			Amount: tokenAmountIn,
 		})
 	}
-	return p.joinPool(ctx, sender, pool, swapTargets, poolAmountOut)
+	if err := p.joinPool(ctx, sender, pool, swapTargets, poolAmountOut); err != nil {
+		return err
+	}
+	emitPoolEvent(ctx, types.EventTypeJoinPool, pool, sender,
+		sdk.NewAttribute(types.AttributeKeyTokensIn, swapTargets.String()),
+		sdk.NewAttribute(types.AttributeKeyPoolSharesOut, poolAmountOut.String()),
+	)
+	return nil
 }
 
 func (p poolService) JoinPoolWithExternAmountIn(
@@ -255,6 +316,7 @@ func (p poolService) JoinPoolWithExternAmountIn(
 	if err != nil {
 		return sdk.Int{}, err
 	}
+	defer recordPoolOpMetrics(time.Now(), types.EventTypeJoinPoolSingleAsset, pool.Id)
 
 	record, ok := pool.Records[tokenIn]
 	if !ok {
@@ -263,21 +325,22 @@ func (p poolService) JoinPoolWithExternAmountIn(
 			"token %s is not bound to this pool", tokenIn,
 		)
 	}
-	if tokenAmountIn.ToDec().GT(record.Balance.ToDec().Mul(maxInRatio)) {
+	if tokenAmountIn.ToDec().GT(record.Balance.ToDec().Mul(p.maxInRatio(ctx))) {
 		return sdk.Int{}, sdkerrors.Wrapf(
 			types.ErrMaxInRatio,
 			"tokenAmount exceeds max in ratio",
 		)
 	}
 
-	poolAmountOut := calcPoolOutGivenSingleIn(
-		record.Balance.ToDec(),
-		record.DenormalizedWeight,
-		pool.Token.TotalSupply.ToDec(),
-		pool.TotalWeight,
-		tokenAmountIn.ToDec(),
-		pool.SwapFee,
-	).TruncateInt()
+	pm, err := newPoolMath(pool.PoolType)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	poolAmountOutDec, err := pm.PoolOutGivenSingleIn(pool, tokenIn, tokenAmountIn.ToDec())
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	poolAmountOut := poolAmountOutDec.TruncateInt()
 
 	if poolAmountOut.LT(minPoolAmountOut) {
 		return sdk.Int{}, sdkerrors.Wrapf(
@@ -286,16 +349,21 @@ func (p poolService) JoinPoolWithExternAmountIn(
 		)
 	}
 
+	tokenInCoin := sdk.Coin{Denom: tokenIn, Amount: tokenAmountIn}
 	if err := p.joinPool(
 		ctx,
 		sender,
 		pool,
-		sdk.Coins{{tokenIn, tokenAmountIn}},
+		sdk.Coins{tokenInCoin},
 		poolAmountOut,
 	); err != nil {
 		return sdk.Int{}, err
 	}
 
+	emitPoolEvent(ctx, types.EventTypeJoinPoolSingleAsset, pool, sender,
+		sdk.NewAttribute(types.AttributeKeyTokensIn, tokenInCoin.String()),
+		sdk.NewAttribute(types.AttributeKeyPoolSharesOut, poolAmountOut.String()),
+	)
 	return poolAmountOut, nil
 }
 
@@ -311,6 +379,7 @@ func (p poolService) JoinPoolWithPoolAmountOut(
 	if err != nil {
 		return sdk.Int{}, err
 	}
+	defer recordPoolOpMetrics(time.Now(), types.EventTypeJoinPoolSingleAsset, pool.Id)
 
 	record, ok := pool.Records[tokenIn]
 	if !ok {
@@ -320,14 +389,15 @@ func (p poolService) JoinPoolWithPoolAmountOut(
 		)
 	}
 
-	tokenAmountIn := calcSingleInGivenPoolOut(
-		record.Balance.ToDec(),
-		record.DenormalizedWeight,
-		pool.Token.TotalSupply.ToDec(),
-		pool.TotalWeight,
-		poolAmountOut.ToDec(),
-		pool.SwapFee,
-	).TruncateInt()
+	pm, err := newPoolMath(pool.PoolType)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	tokenAmountInDec, err := pm.SingleInGivenPoolOut(pool, tokenIn, poolAmountOut.ToDec())
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	tokenAmountIn := tokenAmountInDec.TruncateInt()
 	if tokenAmountIn.Equal(sdk.NewInt(0)) {
 		return sdk.Int{}, sdkerrors.Wrapf(
 			types.ErrMathApprox,
@@ -341,23 +411,28 @@ func (p poolService) JoinPoolWithPoolAmountOut(
 		)
 	}
 
-	if tokenAmountIn.ToDec().GT(record.Balance.ToDec().Mul(maxInRatio)) {
+	if tokenAmountIn.ToDec().GT(record.Balance.ToDec().Mul(p.maxInRatio(ctx))) {
 		return sdk.Int{}, sdkerrors.Wrapf(
 			types.ErrMaxInRatio,
 			"tokenAmount exceeds max in ratio",
 		)
 	}
 
+	tokenInCoin := sdk.Coin{Denom: tokenIn, Amount: tokenAmountIn}
 	if err := p.joinPool(
 		ctx,
 		sender,
 		pool,
-		sdk.Coins{{tokenIn, tokenAmountIn}},
+		sdk.Coins{tokenInCoin},
 		poolAmountOut,
 	); err != nil {
 		return sdk.Int{}, err
 	}
 
+	emitPoolEvent(ctx, types.EventTypeJoinPoolSingleAsset, pool, sender,
+		sdk.NewAttribute(types.AttributeKeyTokensIn, tokenInCoin.String()),
+		sdk.NewAttribute(types.AttributeKeyPoolSharesOut, poolAmountOut.String()),
+	)
 	return poolAmountOut, nil
 }
 
@@ -368,6 +443,10 @@ func (p poolService) exitPool(
 	swapTarget sdk.Int,
 	swapAmounts sdk.Coins,
 ) error {
+	if pool.Frozen {
+		return sdkerrors.Wrapf(types.ErrPoolFrozen, "pool %d is frozen", pool.Id)
+	}
+
 	poolShare := lpService{
 		denom:      pool.Token.Denom,
 		bankKeeper: p.bankKeeper,
@@ -410,6 +489,7 @@ func (p poolService) ExitPool(
 	if err != nil {
 		return err
 	}
+	defer recordPoolOpMetrics(time.Now(), types.EventTypeExitPool, pool.Id)
 	lpToken := pool.Token
 
 	poolTotal := lpToken.TotalSupply.ToDec()
@@ -459,7 +539,14 @@ func (p poolService) ExitPool(
 			Amount: tokenAmountOut,
 		})
 	}
-	return p.exitPool(ctx, sender, pool, poolAmountIn, swapAmounts)
+	if err := p.exitPool(ctx, sender, pool, poolAmountIn, swapAmounts); err != nil {
+		return err
+	}
+	emitPoolEvent(ctx, types.EventTypeExitPool, pool, sender,
+		sdk.NewAttribute(types.AttributeKeyTokensOut, swapAmounts.String()),
+		sdk.NewAttribute(types.AttributeKeyPoolSharesIn, poolAmountIn.String()),
+	)
+	return nil
 }
 
 func (p poolService) ExitPoolWithPoolAmountIn(
@@ -474,6 +561,7 @@ func (p poolService) ExitPoolWithPoolAmountIn(
 	if err != nil {
 		return sdk.Int{}, err
 	}
+	defer recordPoolOpMetrics(time.Now(), types.EventTypeExitPoolSingleAsset, pool.Id)
 
 	record, ok := pool.Records[tokenOut]
 	if !ok {
@@ -483,36 +571,42 @@ func (p poolService) ExitPoolWithPoolAmountIn(
 		)
 	}
 
-	tokenAmountOut := calcSingleOutGivenPoolIn(
-		record.Balance.ToDec(),
-		record.DenormalizedWeight,
-		pool.Token.TotalSupply.ToDec(),
-		pool.TotalWeight,
-		poolAmountIn.ToDec(),
-		pool.SwapFee,
-	).TruncateInt()
+	pm, err := newPoolMath(pool.PoolType)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	tokenAmountOutDec, err := pm.SingleOutGivenPoolIn(pool, tokenOut, poolAmountIn.ToDec())
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	tokenAmountOut := tokenAmountOutDec.TruncateInt()
 	if tokenAmountOut.LT(minAmountOut) {
 		return sdk.Int{}, sdkerrors.Wrapf(
 			types.ErrLimitOut,
 			"tokenAmount minimum limit has exceeded",
 		)
 	}
-	if tokenAmountOut.ToDec().GT(record.Balance.ToDec().Mul(maxOutRatio)) {
+	if tokenAmountOut.ToDec().GT(record.Balance.ToDec().Mul(p.maxOutRatio(ctx))) {
 		return sdk.Int{}, sdkerrors.Wrapf(
 			types.ErrMaxOutRatio,
 			"tokenAmount exceeds max out ratio")
 	}
 
+	tokenOutCoin := sdk.Coin{Denom: tokenOut, Amount: tokenAmountOut}
 	if err := p.exitPool(
 		ctx,
 		sender,
 		pool,
 		poolAmountIn,
-		sdk.Coins{{tokenOut, tokenAmountOut}},
+		sdk.Coins{tokenOutCoin},
 	); err != nil {
 		return sdk.Int{}, err
 	}
 
+	emitPoolEvent(ctx, types.EventTypeExitPoolSingleAsset, pool, sender,
+		sdk.NewAttribute(types.AttributeKeyTokensOut, tokenOutCoin.String()),
+		sdk.NewAttribute(types.AttributeKeyPoolSharesIn, poolAmountIn.String()),
+	)
 	return tokenAmountOut, nil
 }
 
@@ -528,6 +622,7 @@ func (p poolService) ExitPoolWithExternAmountOut(
 	if err != nil {
 		return sdk.Int{}, err
 	}
+	defer recordPoolOpMetrics(time.Now(), types.EventTypeExitPoolSingleAsset, pool.Id)
 
 	record, ok := pool.Records[tokenOut]
 	if !ok {
@@ -536,20 +631,21 @@ func (p poolService) ExitPoolWithExternAmountOut(
 			"token %s is not bound to this pool", tokenOut,
 		)
 	}
-	if tokenAmountOut.ToDec().GT(record.Balance.ToDec().Mul(maxOutRatio)) {
+	if tokenAmountOut.ToDec().GT(record.Balance.ToDec().Mul(p.maxOutRatio(ctx))) {
 		return sdk.Int{}, sdkerrors.Wrapf(
 			types.ErrMaxOutRatio,
 			"tokenAmount exceeds max out ratio")
 	}
 
-	poolAmountIn := calcPoolInGivenSingleOut(
-		record.Balance.ToDec(),
-		record.DenormalizedWeight,
-		pool.Token.TotalSupply.ToDec(),
-		pool.TotalWeight,
-		tokenAmountOut.ToDec(),
-		pool.SwapFee,
-	).TruncateInt()
+	pm, err := newPoolMath(pool.PoolType)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	poolAmountInDec, err := pm.PoolInGivenSingleOut(pool, tokenOut, tokenAmountOut.ToDec())
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	poolAmountIn := poolAmountInDec.TruncateInt()
 	if poolAmountIn.Equal(sdk.NewInt(0)) {
 		return sdk.Int{}, sdkerrors.Wrapf(
 			types.ErrMathApprox,
@@ -563,15 +659,20 @@ func (p poolService) ExitPoolWithExternAmountOut(
 		)
 	}
 
+	tokenOutCoin := sdk.Coin{Denom: tokenOut, Amount: tokenAmountOut}
 	if err := p.exitPool(
 		ctx,
 		sender,
 		pool,
 		poolAmountIn,
-		sdk.Coins{{tokenOut, tokenAmountOut}},
+		sdk.Coins{tokenOutCoin},
 	); err != nil {
 		return sdk.Int{}, err
 	}
 
+	emitPoolEvent(ctx, types.EventTypeExitPoolSingleAsset, pool, sender,
+		sdk.NewAttribute(types.AttributeKeyTokensOut, tokenOutCoin.String()),
+		sdk.NewAttribute(types.AttributeKeyPoolSharesIn, poolAmountIn.String()),
+	)
 	return poolAmountIn, nil
 }
@@ -0,0 +1,291 @@
+package pool
+
+import (
+	"github.com/c-osmosis/osmosis/x/gamm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// LiquidityPoolSwapper is the spot-trading counterpart to
+// LiquidityPoolTransactor: it trades one bound asset for another within a
+// single pool, or across several pools via MultiHopSwap, without minting
+// or burning LP shares.
+type LiquidityPoolSwapper interface {
+	SwapExactAmountIn(
+		ctx sdk.Context,
+		sender sdk.AccAddress,
+		poolId uint64,
+		tokenIn sdk.Coin,
+		tokenOutDenom string,
+		minAmountOut sdk.Int,
+		maxPrice sdk.Dec,
+	) (tokenAmountOut sdk.Int, err error)
+
+	SwapExactAmountOut(
+		ctx sdk.Context,
+		sender sdk.AccAddress,
+		poolId uint64,
+		tokenInDenom string,
+		tokenOut sdk.Coin,
+		maxAmountIn sdk.Int,
+		maxPrice sdk.Dec,
+	) (tokenAmountIn sdk.Int, err error)
+
+	MultiHopSwap(
+		ctx sdk.Context,
+		sender sdk.AccAddress,
+		routes []types.SwapRoute,
+		tokenIn sdk.Coin,
+		minAmountOut sdk.Int,
+	) (tokenAmountOut sdk.Int, err error)
+}
+
+var _ LiquidityPoolSwapper = poolService{}
+
+// cloneRecords returns a shallow copy of records, suitable for computing a
+// hypothetical post-swap pool state (e.g. for a spot-price-after check)
+// without mutating the caller's pool.
+func cloneRecords(records map[string]types.Record) map[string]types.Record {
+	cloned := make(map[string]types.Record, len(records))
+	for denom, record := range records {
+		cloned[denom] = record
+	}
+	return cloned
+}
+
+// swapInPool runs pool's own invariant math (weighted or stableswap) to
+// trade tokenIn into tokenOutDenom within a single already-fetched pool,
+// checks it against maxInRatio and maxPrice, and persists the pool's
+// updated balances. It never touches the bank module: callers own moving
+// tokenIn/tokenOut into and out of the module account, which lets
+// MultiHopSwap chain pools without shuttling intermediate tokens through
+// the sender's balance.
+func (p poolService) swapInPool(
+	ctx sdk.Context,
+	pool types.Pool,
+	tokenIn sdk.Coin,
+	tokenOutDenom string,
+	minAmountOut sdk.Int,
+	maxPrice sdk.Dec,
+) (sdk.Coin, error) {
+	if pool.Frozen {
+		return sdk.Coin{}, sdkerrors.Wrapf(types.ErrPoolFrozen, "pool %d is frozen", pool.Id)
+	}
+
+	pm, err := newPoolMath(pool.PoolType)
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+
+	recordIn, ok := pool.Records[tokenIn.Denom]
+	if !ok {
+		return sdk.Coin{}, sdkerrors.Wrapf(types.ErrNotBound, "token %s is not bound to this pool", tokenIn.Denom)
+	}
+	if _, ok := pool.Records[tokenOutDenom]; !ok {
+		return sdk.Coin{}, sdkerrors.Wrapf(types.ErrNotBound, "token %s is not bound to this pool", tokenOutDenom)
+	}
+
+	if tokenIn.Amount.ToDec().GT(recordIn.Balance.ToDec().Mul(p.maxInRatio(ctx))) {
+		return sdk.Coin{}, sdkerrors.Wrapf(types.ErrMaxInRatio, "tokenAmount exceeds max in ratio")
+	}
+
+	spotPriceBefore, err := pm.SpotPrice(pool, tokenIn.Denom, tokenOutDenom)
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+	if spotPriceBefore.GT(maxPrice) {
+		return sdk.Coin{}, sdkerrors.Wrapf(types.ErrLimitExceed, "spot price above max price limit")
+	}
+
+	tokenAmountOutDec, err := pm.OutGivenIn(pool, tokenIn.Denom, tokenOutDenom, tokenIn.Amount.ToDec())
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+	tokenAmountOut := tokenAmountOutDec.TruncateInt()
+	if tokenAmountOut.LT(minAmountOut) {
+		return sdk.Coin{}, sdkerrors.Wrapf(types.ErrLimitOut, "token amount out minimum limit has exceeded")
+	}
+
+	afterPool := pool
+	afterPool.Records = cloneRecords(pool.Records)
+	afterRecordIn := afterPool.Records[tokenIn.Denom]
+	afterRecordIn.Balance = afterRecordIn.Balance.Add(tokenIn.Amount)
+	afterPool.Records[tokenIn.Denom] = afterRecordIn
+	afterRecordOut := afterPool.Records[tokenOutDenom]
+	afterRecordOut.Balance = afterRecordOut.Balance.Sub(tokenAmountOut)
+	afterPool.Records[tokenOutDenom] = afterRecordOut
+
+	spotPriceAfter, err := pm.SpotPrice(afterPool, tokenIn.Denom, tokenOutDenom)
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+	if spotPriceAfter.GT(maxPrice) {
+		return sdk.Coin{}, sdkerrors.Wrapf(types.ErrLimitExceed, "spot price after swap exceeds max price limit")
+	}
+
+	pool.Records[tokenIn.Denom] = afterRecordIn
+	pool.Records[tokenOutDenom] = afterRecordOut
+	p.store.StorePool(ctx, pool)
+
+	return sdk.NewCoin(tokenOutDenom, tokenAmountOut), nil
+}
+
+func (p poolService) SwapExactAmountIn(
+	ctx sdk.Context,
+	sender sdk.AccAddress,
+	poolId uint64,
+	tokenIn sdk.Coin,
+	tokenOutDenom string,
+	minAmountOut sdk.Int,
+	maxPrice sdk.Dec,
+) (sdk.Int, error) {
+	pool, err := p.store.FetchPool(ctx, poolId)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+
+	if err := p.bankKeeper.SendCoinsFromAccountToModule(ctx, sender, types.ModuleName, sdk.Coins{tokenIn}); err != nil {
+		return sdk.Int{}, err
+	}
+
+	tokenOut, err := p.swapInPool(ctx, pool, tokenIn, tokenOutDenom, minAmountOut, maxPrice)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+
+	if err := p.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, sender, sdk.Coins{tokenOut}); err != nil {
+		return sdk.Int{}, err
+	}
+	return tokenOut.Amount, nil
+}
+
+func (p poolService) SwapExactAmountOut(
+	ctx sdk.Context,
+	sender sdk.AccAddress,
+	poolId uint64,
+	tokenInDenom string,
+	tokenOut sdk.Coin,
+	maxAmountIn sdk.Int,
+	maxPrice sdk.Dec,
+) (sdk.Int, error) {
+	pool, err := p.store.FetchPool(ctx, poolId)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	if pool.Frozen {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrPoolFrozen, "pool %d is frozen", pool.Id)
+	}
+
+	pm, err := newPoolMath(pool.PoolType)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+
+	if _, ok := pool.Records[tokenInDenom]; !ok {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrNotBound, "token %s is not bound to this pool", tokenInDenom)
+	}
+	recordOut, ok := pool.Records[tokenOut.Denom]
+	if !ok {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrNotBound, "token %s is not bound to this pool", tokenOut.Denom)
+	}
+
+	if tokenOut.Amount.ToDec().GT(recordOut.Balance.ToDec().Mul(p.maxOutRatio(ctx))) {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrMaxOutRatio, "tokenAmount exceeds max out ratio")
+	}
+
+	spotPriceBefore, err := pm.SpotPrice(pool, tokenInDenom, tokenOut.Denom)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	if spotPriceBefore.GT(maxPrice) {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrLimitExceed, "spot price above max price limit")
+	}
+
+	tokenAmountInDec, err := pm.InGivenOut(pool, tokenInDenom, tokenOut.Denom, tokenOut.Amount.ToDec())
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	tokenAmountIn := tokenAmountInDec.TruncateInt()
+	if tokenAmountIn.GT(maxAmountIn) {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrLimitIn, "token amount in maximum limit has exceeded")
+	}
+
+	afterPool := pool
+	afterPool.Records = cloneRecords(pool.Records)
+	afterRecordIn := afterPool.Records[tokenInDenom]
+	afterRecordIn.Balance = afterRecordIn.Balance.Add(tokenAmountIn)
+	afterPool.Records[tokenInDenom] = afterRecordIn
+	afterRecordOut := afterPool.Records[tokenOut.Denom]
+	afterRecordOut.Balance = afterRecordOut.Balance.Sub(tokenOut.Amount)
+	afterPool.Records[tokenOut.Denom] = afterRecordOut
+
+	spotPriceAfter, err := pm.SpotPrice(afterPool, tokenInDenom, tokenOut.Denom)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	if spotPriceAfter.GT(maxPrice) {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrLimitExceed, "spot price after swap exceeds max price limit")
+	}
+
+	tokenIn := sdk.NewCoin(tokenInDenom, tokenAmountIn)
+	if err := p.bankKeeper.SendCoinsFromAccountToModule(ctx, sender, types.ModuleName, sdk.Coins{tokenIn}); err != nil {
+		return sdk.Int{}, err
+	}
+
+	pool.Records[tokenInDenom] = afterRecordIn
+	pool.Records[tokenOut.Denom] = afterRecordOut
+	p.store.StorePool(ctx, pool)
+
+	if err := p.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, sender, sdk.Coins{tokenOut}); err != nil {
+		return sdk.Int{}, err
+	}
+	return tokenAmountIn, nil
+}
+
+// MultiHopSwap atomically composes swapInPool across several pools,
+// feeding the output of each hop in as the input of the next. The only
+// bank transfers are tokenIn pulled from sender once at the head and the
+// final hop's output pushed to sender once at the tail; every
+// intermediate amount settles purely through each pool's Records since the
+// underlying coins never leave the module account. Any hop's slippage
+// check failing aborts the whole route and, since every step shares the
+// caller's ctx, unwinds every prior balance change along with it.
+func (p poolService) MultiHopSwap(
+	ctx sdk.Context,
+	sender sdk.AccAddress,
+	routes []types.SwapRoute,
+	tokenIn sdk.Coin,
+	minAmountOut sdk.Int,
+) (sdk.Int, error) {
+	if len(routes) == 0 {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrInvalidRequest, "routes must not be empty")
+	}
+
+	if err := p.bankKeeper.SendCoinsFromAccountToModule(ctx, sender, types.ModuleName, sdk.Coins{tokenIn}); err != nil {
+		return sdk.Int{}, err
+	}
+
+	currentTokenIn := tokenIn
+	for i, route := range routes {
+		pool, err := p.store.FetchPool(ctx, route.PoolId)
+		if err != nil {
+			return sdk.Int{}, sdkerrors.Wrapf(err, "hop %d (pool %d) failed", i, route.PoolId)
+		}
+
+		hopMinAmountOut := sdk.ZeroInt()
+		if i == len(routes)-1 {
+			hopMinAmountOut = minAmountOut
+		}
+
+		tokenOut, err := p.swapInPool(ctx, pool, currentTokenIn, route.TokenOutDenom, hopMinAmountOut, sdk.NewDec(1<<62))
+		if err != nil {
+			return sdk.Int{}, sdkerrors.Wrapf(err, "hop %d (pool %d) failed", i, route.PoolId)
+		}
+		currentTokenIn = tokenOut
+	}
+
+	if err := p.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, sender, sdk.Coins{currentTokenIn}); err != nil {
+		return sdk.Int{}, err
+	}
+	return currentTokenIn.Amount, nil
+}
@@ -0,0 +1,341 @@
+package pool
+
+import (
+	"github.com/c-osmosis/osmosis/x/gamm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// stableswapMaxIterations caps the Newton iteration used to solve the
+// invariant for D and y. 255 mirrors Curve's reference implementation and
+// comfortably converges for any balances this module will realistically
+// see; hitting the cap means the inputs are pathological (e.g. a
+// near-empty reserve) rather than that the loop needs to run longer, so we
+// surface an explicit error instead of returning an unconverged value.
+const stableswapMaxIterations = 255
+
+// stableswapConvergenceThreshold is the maximum |x_n - x_n-1| the Newton
+// loop will accept as converged.
+var stableswapConvergenceThreshold = sdk.NewDecWithPrec(1, 8)
+
+// stableswapPoolMath implements PoolMath for PoolTypeStableswap using the
+// Curve-style invariant A·n^n·Σx_i + D = A·D·n^n + D^(n+1)/(n^n·Πx_i),
+// solved by Newton's method for D (given balances) and y (one unknown
+// balance given D and the rest).
+type stableswapPoolMath struct{}
+
+var _ PoolMath = stableswapPoolMath{}
+
+func (stableswapPoolMath) ValidateCreatePool(bindTokens []types.BindTokenInfo, amplification sdk.Int) error {
+	if amplification.LT(minAmplification) || amplification.GT(maxAmplification) {
+		return sdkerrors.Wrapf(
+			types.ErrInvalidRequest,
+			"amplification factor must be in [%s, %s]", minAmplification, maxAmplification,
+		)
+	}
+	// The Newton solvers divide by each bound asset's balance and return
+	// an error for a zero reserve rather than a price; reject it up front.
+	for _, info := range bindTokens {
+		if !info.Amount.IsPositive() {
+			return sdkerrors.Wrapf(types.ErrInvalidRequest, "balance of %s must be positive", info.Denom)
+		}
+	}
+	return nil
+}
+
+// sortedBalances returns the pool's bound-token balances in a
+// deterministic (denom-sorted) order, matching pool.SortedDenoms().
+func sortedBalances(pool types.Pool) []sdk.Dec {
+	denoms := pool.SortedDenoms()
+	balances := make([]sdk.Dec, 0, len(denoms))
+	for _, denom := range denoms {
+		balances = append(balances, pool.Records[denom].Balance.ToDec())
+	}
+	return balances
+}
+
+// stableswapD solves the invariant for D given a set of balances and the
+// amplification factor, via Newton's method.
+func stableswapD(balances []sdk.Dec, amplification sdk.Dec) (sdk.Dec, error) {
+	n := sdk.NewDec(int64(len(balances)))
+
+	s := sdk.ZeroDec()
+	for _, x := range balances {
+		s = s.Add(x)
+	}
+	if s.IsZero() {
+		return sdk.ZeroDec(), nil
+	}
+
+	ann := amplification.Mul(n.Power(uint64(len(balances))))
+	d := s
+	for i := 0; i < stableswapMaxIterations; i++ {
+		dP := d
+		for _, x := range balances {
+			if x.IsZero() {
+				return sdk.Dec{}, sdkerrors.Wrapf(types.ErrMathApprox, "D: degenerate reserves")
+			}
+			dP = dP.Mul(d).Quo(x.Mul(n))
+		}
+		dPrev := d
+		numerator := ann.Mul(s).Add(dP.Mul(n)).Mul(d)
+		denominator := ann.Sub(sdk.OneDec()).Mul(d).Add(n.Add(sdk.OneDec()).Mul(dP))
+		d = numerator.Quo(denominator)
+
+		if d.Sub(dPrev).Abs().LTE(stableswapConvergenceThreshold) {
+			return d, nil
+		}
+	}
+	return sdk.Dec{}, sdkerrors.Wrapf(types.ErrMathApprox, "D failed to converge after %d iterations", stableswapMaxIterations)
+}
+
+// stableswapY solves the invariant for the balance of the asset at index
+// j, given D, the amplification factor, and every other asset's balance
+// (balances[j] is ignored).
+func stableswapY(balances []sdk.Dec, j int, d sdk.Dec, amplification sdk.Dec) (sdk.Dec, error) {
+	n := sdk.NewDec(int64(len(balances)))
+	ann := amplification.Mul(n.Power(uint64(len(balances))))
+
+	s := sdk.ZeroDec()
+	c := d
+	for k, x := range balances {
+		if k == j {
+			continue
+		}
+		if x.IsZero() {
+			return sdk.Dec{}, sdkerrors.Wrapf(types.ErrMathApprox, "y: degenerate reserves")
+		}
+		s = s.Add(x)
+		c = c.Mul(d).Quo(x.Mul(n))
+	}
+	c = c.Mul(d).Quo(ann.Mul(n))
+	b := s.Add(d.Quo(ann))
+
+	y := d
+	for i := 0; i < stableswapMaxIterations; i++ {
+		yPrev := y
+		numerator := y.Mul(y).Add(c)
+		denominator := y.MulInt64(2).Add(b).Sub(d)
+		if !denominator.IsPositive() {
+			return sdk.Dec{}, sdkerrors.Wrapf(types.ErrMathApprox, "y failed to converge: degenerate reserves")
+		}
+		y = numerator.Quo(denominator)
+
+		if y.Sub(yPrev).Abs().LTE(stableswapConvergenceThreshold) {
+			return y, nil
+		}
+	}
+	return sdk.Dec{}, sdkerrors.Wrapf(types.ErrMathApprox, "y failed to converge after %d iterations", stableswapMaxIterations)
+}
+
+// denomIndex returns the position of denom in the pool's sorted balance
+// slice, matching the ordering sortedBalances produces.
+func denomIndex(pool types.Pool, denom string) int {
+	denoms := pool.SortedDenoms()
+	for i, d := range denoms {
+		if d == denom {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s stableswapPoolMath) PoolOutGivenSingleIn(pool types.Pool, tokenInDenom string, tokenAmountIn sdk.Dec) (sdk.Dec, error) {
+	amp := pool.Amplification.ToDec()
+	balances := sortedBalances(pool)
+	i := denomIndex(pool, tokenInDenom)
+
+	d0, err := stableswapD(balances, amp)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+
+	amountAfterFee := tokenAmountIn.Mul(sdk.OneDec().Sub(pool.SwapFee))
+	balances[i] = balances[i].Add(amountAfterFee)
+
+	d1, err := stableswapD(balances, amp)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+
+	if d0.IsZero() {
+		return sdk.ZeroDec(), nil
+	}
+	return pool.Token.TotalSupply.ToDec().Mul(d1.Sub(d0)).Quo(d0), nil
+}
+
+func (s stableswapPoolMath) SingleInGivenPoolOut(pool types.Pool, tokenInDenom string, poolAmountOut sdk.Dec) (sdk.Dec, error) {
+	amp := pool.Amplification.ToDec()
+	balances := sortedBalances(pool)
+	i := denomIndex(pool, tokenInDenom)
+
+	d0, err := stableswapD(balances, amp)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	d1 := d0.Mul(pool.Token.TotalSupply.ToDec().Add(poolAmountOut)).Quo(pool.Token.TotalSupply.ToDec())
+
+	newBalanceIn, err := stableswapY(balances, i, d1, amp)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+
+	amountInAfterFee := newBalanceIn.Sub(balances[i])
+	return amountInAfterFee.Quo(sdk.OneDec().Sub(pool.SwapFee)), nil
+}
+
+func (s stableswapPoolMath) SingleOutGivenPoolIn(pool types.Pool, tokenOutDenom string, poolAmountIn sdk.Dec) (sdk.Dec, error) {
+	amp := pool.Amplification.ToDec()
+	balances := sortedBalances(pool)
+	i := denomIndex(pool, tokenOutDenom)
+
+	d0, err := stableswapD(balances, amp)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	d1 := d0.Mul(pool.Token.TotalSupply.ToDec().Sub(poolAmountIn)).Quo(pool.Token.TotalSupply.ToDec())
+
+	newBalanceOut, err := stableswapY(balances, i, d1, amp)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+
+	amountOutBeforeFee := balances[i].Sub(newBalanceOut)
+	return amountOutBeforeFee.Mul(sdk.OneDec().Sub(pool.SwapFee)), nil
+}
+
+func (s stableswapPoolMath) PoolInGivenSingleOut(pool types.Pool, tokenOutDenom string, tokenAmountOut sdk.Dec) (sdk.Dec, error) {
+	amp := pool.Amplification.ToDec()
+	balances := sortedBalances(pool)
+	i := denomIndex(pool, tokenOutDenom)
+
+	d0, err := stableswapD(balances, amp)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+
+	amountOutBeforeFee := tokenAmountOut.Quo(sdk.OneDec().Sub(pool.SwapFee))
+	balances[i] = balances[i].Sub(amountOutBeforeFee)
+
+	d1, err := stableswapD(balances, amp)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+
+	return pool.Token.TotalSupply.ToDec().Mul(d0.Sub(d1)).Quo(d0), nil
+}
+
+// OutGivenIn solves the invariant for the amount of tokenOutDenom a
+// trader receives for an exact tokenAmountIn of tokenInDenom, holding D
+// fixed (a swap doesn't change the invariant, only a trade's worth of
+// one asset for another) and solving stableswapY for the new balance of
+// tokenOutDenom after tokenInDenom's balance is bumped by the
+// fee-adjusted input.
+func (s stableswapPoolMath) OutGivenIn(pool types.Pool, tokenInDenom, tokenOutDenom string, tokenAmountIn sdk.Dec) (sdk.Dec, error) {
+	amp := pool.Amplification.ToDec()
+	balances := sortedBalances(pool)
+	i := denomIndex(pool, tokenInDenom)
+	j := denomIndex(pool, tokenOutDenom)
+	if i < 0 {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrNotBound, "token %s is not bound to this pool", tokenInDenom)
+	}
+	if j < 0 {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrNotBound, "token %s is not bound to this pool", tokenOutDenom)
+	}
+
+	d, err := stableswapD(balances, amp)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+
+	newBalances := make([]sdk.Dec, len(balances))
+	copy(newBalances, balances)
+	newBalances[i] = newBalances[i].Add(tokenAmountIn.Mul(sdk.OneDec().Sub(pool.SwapFee)))
+
+	newBalanceOut, err := stableswapY(newBalances, j, d, amp)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+
+	tokenAmountOut := balances[j].Sub(newBalanceOut)
+	if !tokenAmountOut.IsPositive() {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrMathApprox, "swap: degenerate reserves")
+	}
+	return tokenAmountOut, nil
+}
+
+// InGivenOut is the inverse of OutGivenIn: given an exact tokenAmountOut
+// of tokenOutDenom a trader wants, how much of tokenInDenom they must pay
+// in, again holding D fixed.
+func (s stableswapPoolMath) InGivenOut(pool types.Pool, tokenInDenom, tokenOutDenom string, tokenAmountOut sdk.Dec) (sdk.Dec, error) {
+	amp := pool.Amplification.ToDec()
+	balances := sortedBalances(pool)
+	i := denomIndex(pool, tokenInDenom)
+	j := denomIndex(pool, tokenOutDenom)
+	if i < 0 {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrNotBound, "token %s is not bound to this pool", tokenInDenom)
+	}
+	if j < 0 {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrNotBound, "token %s is not bound to this pool", tokenOutDenom)
+	}
+
+	d, err := stableswapD(balances, amp)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+
+	newBalances := make([]sdk.Dec, len(balances))
+	copy(newBalances, balances)
+	newBalances[j] = newBalances[j].Sub(tokenAmountOut)
+	if !newBalances[j].IsPositive() {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrLimitOut, "tokenAmountOut exceeds pool balance of %s", tokenOutDenom)
+	}
+
+	newBalanceIn, err := stableswapY(newBalances, i, d, amp)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+
+	amountInBeforeFee := newBalanceIn.Sub(balances[i])
+	return amountInBeforeFee.Quo(sdk.OneDec().Sub(pool.SwapFee)), nil
+}
+
+// spotPriceEpsilon is the fraction of the base asset's balance perturbed
+// to estimate the stableswap invariant's marginal price by finite
+// difference; the invariant has no closed form for price the way the
+// weighted-pool formula does.
+var spotPriceEpsilon = sdk.NewDecWithPrec(1, 6)
+
+func (s stableswapPoolMath) SpotPrice(pool types.Pool, baseDenom, quoteDenom string) (sdk.Dec, error) {
+	amp := pool.Amplification.ToDec()
+	balances := sortedBalances(pool)
+	i := denomIndex(pool, baseDenom)
+	j := denomIndex(pool, quoteDenom)
+	if i < 0 {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrNotBound, "token %s is not bound to this pool", baseDenom)
+	}
+	if j < 0 {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrNotBound, "token %s is not bound to this pool", quoteDenom)
+	}
+
+	d, err := stableswapD(balances, amp)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+
+	perturbed := make([]sdk.Dec, len(balances))
+	copy(perturbed, balances)
+	delta := balances[i].Mul(spotPriceEpsilon)
+	perturbed[i] = perturbed[i].Add(delta)
+
+	newBalanceQuote, err := stableswapY(perturbed, j, d, amp)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+
+	quoteMoved := balances[j].Sub(newBalanceQuote)
+	if !quoteMoved.IsPositive() {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrMathApprox, "spot price: degenerate reserves")
+	}
+	return delta.Quo(quoteMoved), nil
+}
@@ -0,0 +1,145 @@
+package pool
+
+import (
+	"testing"
+
+	"github.com/c-osmosis/osmosis/x/gamm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// newStableswapPool builds a minimal stableswap types.Pool with the given
+// swap fee and denom/balance pairs bound, for exercising the Newton
+// solvers in isolation from CreatePool/JoinPool.
+func newStableswapPool(amplification int64, swapFee sdk.Dec, balances map[string]int64) types.Pool {
+	records := make(map[string]types.Record, len(balances))
+	for denom, balance := range balances {
+		records[denom] = types.Record{Balance: sdk.NewInt(balance)}
+	}
+	return types.Pool{
+		Id:            1,
+		SwapFee:       swapFee,
+		Token:         types.LP{Denom: "osmosis/pool/1", TotalSupply: sdk.NewInt(0)},
+		PoolType:      types.PoolTypeStableswap,
+		Amplification: sdk.NewInt(amplification),
+		Records:       records,
+	}
+}
+
+// decApproxEqual reports whether a and b are within tolerance of each
+// other, for asserting on Newton-solver output that converges to within
+// stableswapConvergenceThreshold rather than exactly.
+func decApproxEqual(t *testing.T, a, b sdk.Dec, tolerance sdk.Dec) {
+	t.Helper()
+	if a.Sub(b).Abs().GT(tolerance) {
+		t.Fatalf("expected %s and %s to be within %s, diff %s", a, b, tolerance, a.Sub(b).Abs())
+	}
+}
+
+func TestStableswapD_SymmetricTwoAsset(t *testing.T) {
+	pool := newStableswapPool(100, sdk.ZeroDec(), map[string]int64{"uusdc": 1000, "uusdt": 1000})
+	d, err := stableswapD(sortedBalances(pool), pool.Amplification.ToDec())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// At perfectly balanced reserves, D equals the sum of balances
+	// regardless of amplification - that's the invariant's fixed point.
+	decApproxEqual(t, d, sdk.NewDec(2000), stableswapConvergenceThreshold)
+}
+
+func TestStableswapD_SymmetricThreeAsset(t *testing.T) {
+	pool := newStableswapPool(100, sdk.ZeroDec(), map[string]int64{"uusdc": 500, "uusdt": 500, "udai": 500})
+	d, err := stableswapD(sortedBalances(pool), pool.Amplification.ToDec())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	decApproxEqual(t, d, sdk.NewDec(1500), stableswapConvergenceThreshold)
+}
+
+func TestStableswapD_DegenerateNearEmptyReserves(t *testing.T) {
+	pool := newStableswapPool(100, sdk.ZeroDec(), map[string]int64{"uusdc": 1, "uusdt": 1})
+	d, err := stableswapD(sortedBalances(pool), pool.Amplification.ToDec())
+	if err != nil {
+		t.Fatalf("unexpected error on near-empty reserves: %v", err)
+	}
+	if !d.IsPositive() {
+		t.Fatalf("expected a positive D, got %s", d)
+	}
+}
+
+func TestStableswapD_ZeroReservesReturnsZero(t *testing.T) {
+	pool := newStableswapPool(100, sdk.ZeroDec(), map[string]int64{"uusdc": 0, "uusdt": 0})
+	d, err := stableswapD(sortedBalances(pool), pool.Amplification.ToDec())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !d.IsZero() {
+		t.Fatalf("expected D of zero for empty reserves, got %s", d)
+	}
+}
+
+func TestStableswapD_OneSidedReserveReturnsErrorNotPanic(t *testing.T) {
+	pool := newStableswapPool(100, sdk.ZeroDec(), map[string]int64{"uusdc": 1000, "uusdt": 0})
+	if _, err := stableswapD(sortedBalances(pool), pool.Amplification.ToDec()); err == nil {
+		t.Fatalf("expected an error for a one-sided [x,0] reserve, got none")
+	}
+}
+
+func TestStableswapPoolMath_ValidateCreatePoolRejectsZeroBalance(t *testing.T) {
+	pm := stableswapPoolMath{}
+	bindTokens := []types.BindTokenInfo{
+		{Denom: "uusdc", Amount: sdk.NewInt(1000)},
+		{Denom: "uusdt", Amount: sdk.ZeroInt()},
+	}
+	if err := pm.ValidateCreatePool(bindTokens, sdk.NewInt(100)); err == nil {
+		t.Fatalf("expected an error for a zero-balance bind token, got none")
+	}
+}
+
+func TestStableswapPoolMath_SpotPriceBalanced(t *testing.T) {
+	pool := newStableswapPool(100, sdk.ZeroDec(), map[string]int64{"uusdc": 1000, "uusdt": 1000})
+	pm := stableswapPoolMath{}
+	price, err := pm.SpotPrice(pool, "uusdc", "uusdt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	decApproxEqual(t, price, sdk.OneDec(), sdk.NewDecWithPrec(1, 4))
+}
+
+func TestStableswapPoolMath_OutGivenInThenInGivenOutRoundTrips(t *testing.T) {
+	pool := newStableswapPool(100, sdk.ZeroDec(), map[string]int64{"uusdc": 1_000_000, "uusdt": 1_000_000, "udai": 1_000_000})
+	pm := stableswapPoolMath{}
+
+	tokenAmountIn := sdk.NewDec(1000)
+	tokenAmountOut, err := pm.OutGivenIn(pool, "uusdc", "uusdt", tokenAmountIn)
+	if err != nil {
+		t.Fatalf("OutGivenIn: unexpected error: %v", err)
+	}
+	if !tokenAmountOut.IsPositive() {
+		t.Fatalf("expected a positive amount out, got %s", tokenAmountOut)
+	}
+	// A zero-fee stableswap trade near the peg should lose very little to
+	// slippage: out should stay close to in.
+	decApproxEqual(t, tokenAmountOut, tokenAmountIn, sdk.NewDecWithPrec(1, 1))
+
+	recoveredIn, err := pm.InGivenOut(pool, "uusdc", "uusdt", tokenAmountOut)
+	if err != nil {
+		t.Fatalf("InGivenOut: unexpected error: %v", err)
+	}
+	decApproxEqual(t, recoveredIn, tokenAmountIn, sdk.NewDecWithPrec(1, 1))
+}
+
+func TestStableswapPoolMath_OutGivenInRejectsUnboundDenom(t *testing.T) {
+	pool := newStableswapPool(100, sdk.ZeroDec(), map[string]int64{"uusdc": 1000, "uusdt": 1000})
+	pm := stableswapPoolMath{}
+	if _, err := pm.OutGivenIn(pool, "uusdc", "ukava", sdk.NewDec(10)); err == nil {
+		t.Fatalf("expected an error for an unbound tokenOutDenom")
+	}
+}
+
+func TestStableswapPoolMath_InGivenOutRejectsExceedingBalance(t *testing.T) {
+	pool := newStableswapPool(100, sdk.ZeroDec(), map[string]int64{"uusdc": 1000, "uusdt": 1000})
+	pm := stableswapPoolMath{}
+	if _, err := pm.InGivenOut(pool, "uusdc", "uusdt", sdk.NewDec(1000)); err == nil {
+		t.Fatalf("expected an error when tokenAmountOut drains the pool's entire balance")
+	}
+}
@@ -0,0 +1,20 @@
+package types
+
+import (
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// x/gamm module sentinel errors.
+var (
+	ErrInvalidRequest = sdkerrors.Register(ModuleName, 2, "invalid request")
+	ErrMathApprox     = sdkerrors.Register(ModuleName, 3, "math approx error")
+	ErrLimitExceed    = sdkerrors.Register(ModuleName, 4, "limit exceeded")
+	ErrNotBound       = sdkerrors.Register(ModuleName, 5, "token is not bound to this pool")
+	ErrMaxInRatio     = sdkerrors.Register(ModuleName, 6, "exceeds max in ratio")
+	ErrMaxOutRatio    = sdkerrors.Register(ModuleName, 7, "exceeds max out ratio")
+	ErrLimitOut       = sdkerrors.Register(ModuleName, 8, "minimum out amount not met")
+	ErrLimitIn        = sdkerrors.Register(ModuleName, 9, "maximum in amount exceeded")
+	ErrPoolNotFound   = sdkerrors.Register(ModuleName, 10, "pool not found")
+	ErrPoolFrozen     = sdkerrors.Register(ModuleName, 11, "pool is frozen")
+	ErrUnauthorized   = sdkerrors.Register(ModuleName, 12, "unauthorized")
+)
@@ -0,0 +1,21 @@
+package types
+
+// Event types and attribute keys emitted by pool lifecycle and liquidity
+// operations.
+const (
+	EventTypeCreatePool          = "create_pool"
+	EventTypeJoinPool            = "join_pool"
+	EventTypeExitPool            = "exit_pool"
+	EventTypeJoinPoolSingleAsset = "join_pool_single_asset"
+	EventTypeExitPoolSingleAsset = "exit_pool_single_asset"
+	EventTypeFreezePool          = "freeze_pool"
+	EventTypeUnfreezePool        = "unfreeze_pool"
+
+	AttributeKeyPoolId         = "pool_id"
+	AttributeKeySender         = "sender"
+	AttributeKeyTokensIn       = "tokens_in"
+	AttributeKeyTokensOut      = "tokens_out"
+	AttributeKeyPoolSharesIn   = "pool_shares_in"
+	AttributeKeyPoolSharesOut  = "pool_shares_out"
+	AttributeKeySpotPriceAfter = "spot_price_after"
+)
@@ -0,0 +1,23 @@
+package types
+
+const (
+	// ModuleName is the name of the gamm module.
+	ModuleName = "gamm"
+
+	// StoreKey is the default store key for the gamm module.
+	StoreKey = ModuleName
+
+	// RouterKey is the message route for the gamm module.
+	RouterKey = ModuleName
+
+	// QuerierRoute is the querier route for the gamm module.
+	QuerierRoute = ModuleName
+)
+
+var (
+	// PoolsKeyPrefix is the prefix under which individual pools are stored.
+	PoolsKeyPrefix = []byte{0x01}
+
+	// NextPoolNumberKey stores the next pool id to be assigned by CreatePool.
+	NextPoolNumberKey = []byte{0x02}
+)
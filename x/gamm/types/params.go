@@ -0,0 +1,191 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+)
+
+// Parameter store keys for the gamm module's governance-controlled params.
+var (
+	KeyMaxInRatio        = []byte("MaxInRatio")
+	KeyMaxOutRatio       = []byte("MaxOutRatio")
+	KeyMinSwapFee        = []byte("MinSwapFee")
+	KeyMaxSwapFee        = []byte("MaxSwapFee")
+	KeyMinPoolAssets     = []byte("MinPoolAssets")
+	KeyMaxPoolAssets     = []byte("MaxPoolAssets")
+	KeyInitialPoolShares = []byte("InitialPoolShares")
+	KeyPoolCreationFee   = []byte("PoolCreationFee")
+)
+
+// Default param values, matching the bounds that used to be hardcoded in
+// the pool keeper.
+var (
+	DefaultMaxInRatio        = sdk.NewDecWithPrec(5, 1) // 0.5
+	DefaultMaxOutRatio       = sdk.NewDecWithPrec(3, 1) // 0.3
+	DefaultMinSwapFee        = sdk.ZeroDec()
+	DefaultMaxSwapFee        = sdk.NewDecWithPrec(1, 1) // 0.1
+	DefaultMinPoolAssets     = uint32(2)
+	DefaultMaxPoolAssets     = uint32(8)
+	DefaultInitialPoolShares = sdk.NewIntWithDecimal(100, 6)
+	DefaultPoolCreationFee   = sdk.Coins{}
+)
+
+// Params holds the governance-controlled limits and fees CreatePool and the
+// swap/join/exit paths are bound by.
+type Params struct {
+	MaxInRatio        sdk.Dec
+	MaxOutRatio       sdk.Dec
+	MinSwapFee        sdk.Dec
+	MaxSwapFee        sdk.Dec
+	MinPoolAssets     uint32
+	MaxPoolAssets     uint32
+	InitialPoolShares sdk.Int
+	PoolCreationFee   sdk.Coins
+}
+
+var _ paramtypes.ParamSet = (*Params)(nil)
+
+// NewParams constructs a Params from its fields.
+func NewParams(
+	maxInRatio, maxOutRatio, minSwapFee, maxSwapFee sdk.Dec,
+	minPoolAssets, maxPoolAssets uint32,
+	initialPoolShares sdk.Int,
+	poolCreationFee sdk.Coins,
+) Params {
+	return Params{
+		MaxInRatio:        maxInRatio,
+		MaxOutRatio:       maxOutRatio,
+		MinSwapFee:        minSwapFee,
+		MaxSwapFee:        maxSwapFee,
+		MinPoolAssets:     minPoolAssets,
+		MaxPoolAssets:     maxPoolAssets,
+		InitialPoolShares: initialPoolShares,
+		PoolCreationFee:   poolCreationFee,
+	}
+}
+
+// DefaultParams returns the default gamm params.
+func DefaultParams() Params {
+	return NewParams(
+		DefaultMaxInRatio,
+		DefaultMaxOutRatio,
+		DefaultMinSwapFee,
+		DefaultMaxSwapFee,
+		DefaultMinPoolAssets,
+		DefaultMaxPoolAssets,
+		DefaultInitialPoolShares,
+		DefaultPoolCreationFee,
+	)
+}
+
+// ParamKeyTable returns the key table for the gamm module's param subspace.
+func ParamKeyTable() paramtypes.KeyTable {
+	return paramtypes.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// ParamSetPairs implements paramtypes.ParamSet.
+func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
+	return paramtypes.ParamSetPairs{
+		paramtypes.NewParamSetPair(KeyMaxInRatio, &p.MaxInRatio, validateRatio),
+		paramtypes.NewParamSetPair(KeyMaxOutRatio, &p.MaxOutRatio, validateRatio),
+		paramtypes.NewParamSetPair(KeyMinSwapFee, &p.MinSwapFee, validateSwapFee),
+		paramtypes.NewParamSetPair(KeyMaxSwapFee, &p.MaxSwapFee, validateSwapFee),
+		paramtypes.NewParamSetPair(KeyMinPoolAssets, &p.MinPoolAssets, validatePoolAssetCount),
+		paramtypes.NewParamSetPair(KeyMaxPoolAssets, &p.MaxPoolAssets, validatePoolAssetCount),
+		paramtypes.NewParamSetPair(KeyInitialPoolShares, &p.InitialPoolShares, validateInitialPoolShares),
+		paramtypes.NewParamSetPair(KeyPoolCreationFee, &p.PoolCreationFee, validatePoolCreationFee),
+	}
+}
+
+// Validate checks that every field is individually valid and that the
+// min/max pairs are consistent with each other.
+func (p Params) Validate() error {
+	if err := validateRatio(p.MaxInRatio); err != nil {
+		return err
+	}
+	if err := validateRatio(p.MaxOutRatio); err != nil {
+		return err
+	}
+	if err := validateSwapFee(p.MinSwapFee); err != nil {
+		return err
+	}
+	if err := validateSwapFee(p.MaxSwapFee); err != nil {
+		return err
+	}
+	if p.MinSwapFee.GT(p.MaxSwapFee) {
+		return fmt.Errorf("min swap fee %s is greater than max swap fee %s", p.MinSwapFee, p.MaxSwapFee)
+	}
+	if err := validatePoolAssetCount(p.MinPoolAssets); err != nil {
+		return err
+	}
+	if err := validatePoolAssetCount(p.MaxPoolAssets); err != nil {
+		return err
+	}
+	if p.MinPoolAssets > p.MaxPoolAssets {
+		return fmt.Errorf("min pool assets %d is greater than max pool assets %d", p.MinPoolAssets, p.MaxPoolAssets)
+	}
+	if err := validateInitialPoolShares(p.InitialPoolShares); err != nil {
+		return err
+	}
+	return validatePoolCreationFee(p.PoolCreationFee)
+}
+
+func validateRatio(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if v.IsNil() || !v.IsPositive() || v.GT(sdk.OneDec()) {
+		return fmt.Errorf("ratio must be in (0, 1]: %s", v)
+	}
+	return nil
+}
+
+func validateSwapFee(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if v.IsNil() || v.IsNegative() || v.GTE(sdk.OneDec()) {
+		return fmt.Errorf("swap fee must be in [0, 1): %s", v)
+	}
+	return nil
+}
+
+func validatePoolAssetCount(i interface{}) error {
+	v, ok := i.(uint32)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if v < 2 {
+		return fmt.Errorf("pool asset count bound must be at least 2: %d", v)
+	}
+	return nil
+}
+
+func validateInitialPoolShares(i interface{}) error {
+	v, ok := i.(sdk.Int)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if v.IsNil() || !v.IsPositive() {
+		return fmt.Errorf("initial pool shares must be positive: %s", v)
+	}
+	return nil
+}
+
+func validatePoolCreationFee(i interface{}) error {
+	v, ok := i.(sdk.Coins)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if v == nil {
+		return fmt.Errorf("pool creation fee must not be nil")
+	}
+	if !v.IsValid() {
+		return fmt.Errorf("invalid pool creation fee: %s", v)
+	}
+	return nil
+}
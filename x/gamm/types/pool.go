@@ -0,0 +1,106 @@
+package types
+
+import (
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// LPTokenInfo describes the liquidity-provider share token that a newly
+// created pool should mint.
+type LPTokenInfo struct {
+	Denom       string
+	Description string
+	// TransferRestricted, if set, carries over to the created pool's
+	// Pool.TransferRestricted.
+	TransferRestricted bool
+}
+
+// BindTokenInfo describes a single asset bound to a pool at creation time.
+type BindTokenInfo struct {
+	Denom  string
+	Amount sdk.Int
+	Weight sdk.Dec
+}
+
+// MaxAmountIn caps the amount of a given denom a sender is willing to
+// deposit in JoinPool.
+type MaxAmountIn struct {
+	Denom     string
+	MaxAmount sdk.Int
+}
+
+// MinAmountOut floors the amount of a given denom a sender is willing to
+// receive from ExitPool.
+type MinAmountOut struct {
+	Denom     string
+	MinAmount sdk.Int
+}
+
+// LP is the liquidity-provider share token minted by a pool.
+type LP struct {
+	Denom       string
+	Description string
+	TotalSupply sdk.Int
+}
+
+// Record is the per-denom bookkeeping a pool keeps for one of its bound
+// assets.
+type Record struct {
+	DenormalizedWeight sdk.Dec
+	Balance            sdk.Int
+}
+
+// PoolType discriminates the invariant math a pool is governed by.
+type PoolType int32
+
+const (
+	// PoolTypeWeighted is the original constant-weighted-product pool.
+	PoolTypeWeighted PoolType = iota
+	// PoolTypeStableswap is a Curve-style low-slippage pool for
+	// like-valued assets, parameterized by an amplification factor.
+	PoolTypeStableswap
+)
+
+// Pool is a single gamm liquidity pool.
+type Pool struct {
+	Id      uint64
+	SwapFee sdk.Dec
+	Token   LP
+	// PoolType selects the invariant math (see PoolMath in the pool
+	// keeper) this pool's join/exit/swap operations are computed with.
+	PoolType PoolType
+	// TotalWeight is the sum of every bound asset's DenormalizedWeight.
+	// Unused (zero) for PoolTypeStableswap, where assets are weighted
+	// equally by construction.
+	TotalWeight sdk.Dec
+	// Amplification is the stableswap "A" parameter. Unused for
+	// PoolTypeWeighted.
+	Amplification sdk.Int
+	Records       map[string]Record
+	// Frozen halts JoinPool, ExitPool, and every swap against this pool.
+	// It is a circuit breaker for governance or Admin to pull during an
+	// emergency; it does not affect ExportGenesis/InitGenesis.
+	Frozen bool
+	// Admin, if set, may FreezePool/UnfreezePool this pool without going
+	// through governance. CreatePool defaults it to the pool's creator.
+	Admin sdk.AccAddress
+	// TransferRestricted, if set, confines this pool's LP share denom to
+	// moving only to/from the gamm module account, i.e. only via
+	// join/exit, never a plain bank send between two regular accounts.
+	// Enforced by the SendRestrictionFn in restrictions.go.
+	TransferRestricted bool
+}
+
+// SortedDenoms returns the denoms of p.Records in sorted order. Records is
+// a map, so ranging over it directly is nondeterministic across nodes;
+// every call site that needs to iterate every bound asset (rather than
+// look one up by denom) should range over this instead.
+func (p Pool) SortedDenoms() []string {
+	denoms := make([]string, 0, len(p.Records))
+	for denom := range p.Records {
+		denoms = append(denoms, denom)
+	}
+	sort.Strings(denoms)
+	return denoms
+}
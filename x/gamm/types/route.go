@@ -0,0 +1,9 @@
+package types
+
+// SwapRoute is a single hop of a MultiHopSwap: swap into the pool
+// identified by PoolId and take the output in TokenOutDenom, then feed
+// that amount into the next hop.
+type SwapRoute struct {
+	PoolId        uint64
+	TokenOutDenom string
+}